@@ -4,16 +4,39 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/CassianoJunior/go-react/internal/api"
+	"github.com/CassianoJunior/go-react/internal/broker"
 	"github.com/CassianoJunior/go-react/internal/store/pgstore"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 )
 
+const defaultShutdownTimeout = 10 * time.Second
+
+// shutdownTimeout reads SHUTDOWN_TIMEOUT_SECONDS, defaulting to
+// defaultShutdownTimeout when unset or invalid.
+func shutdownTimeout() time.Duration {
+	raw := os.Getenv("SHUTDOWN_TIMEOUT_SECONDS")
+	if raw == "" {
+		return defaultShutdownTimeout
+	}
+
+	seconds, err := strconv.Atoi(raw)
+	if err != nil || seconds <= 0 {
+		return defaultShutdownTimeout
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
 func main() {
 	if err := godotenv.Load(); err != nil {
 		panic(err.Error())
@@ -34,16 +57,22 @@ func main() {
 		panic(err.Error())
 	}
 
-	defer pool.Close()
-
 	if err := pool.Ping(ctx); err != nil {
 		panic(err.Error())
 	}
 
-	handler := api.NewHandler(pgstore.New(pool))
+	handler, err := api.NewHandler(pgstore.New(pool), broker.New(pool))
+	if err != nil {
+		panic(err.Error())
+	}
+
+	srv := &http.Server{
+		Addr:    ":3333",
+		Handler: handler,
+	}
 
 	go func() {
-		if err := http.ListenAndServe(":3333", handler); err != nil {
+		if err := srv.ListenAndServe(); err != nil {
 			if !errors.Is(err, http.ErrServerClosed) {
 				panic(err.Error())
 			}
@@ -51,6 +80,21 @@ func main() {
 	}()
 
 	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	<-quit
+
+	slog.Info("Shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout())
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Failed to shut down HTTP server", "error", err.Error())
+	}
+
+	if err := handler.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Failed to drain websocket subscribers", "error", err.Error())
+	}
+
+	pool.Close()
 }
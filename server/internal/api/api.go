@@ -4,32 +4,172 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/CassianoJunior/go-react/internal/auth"
+	"github.com/CassianoJunior/go-react/internal/broker"
 	"github.com/CassianoJunior/go-react/internal/store/pgstore"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	"github.com/google/uuid"
+	"github.com/gorilla/sessions"
 	"github.com/gorilla/websocket"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// Handler is what NewHandler returns: an http.Handler that also exposes a
+// graceful Shutdown so the caller can drain live WebSocket subscribers
+// before closing the process down.
+type Handler interface {
+	http.Handler
+	Shutdown(ctx context.Context) error
+}
+
+// roomShard holds one room's local subscribers, and the unsubscribe func
+// for this process's single broker subscription feeding them, behind
+// their own lock, so broadcasting to one room never blocks a
+// subscribe/unsubscribe happening on another.
+type roomShard struct {
+	mutex       sync.RWMutex
+	subscribers map[*subscriber]struct{}
+	unsubscribe func()
+}
+
 type apiHandler struct {
-	queries     *pgstore.Queries
-	router      *chi.Mux
-	upgrader    websocket.Upgrader
-	subscribers map[string]map[*websocket.Conn]context.CancelFunc
-	mutex       *sync.Mutex
+	queries  *pgstore.Queries
+	router   *chi.Mux
+	upgrader websocket.Upgrader
+	rooms    map[string]*roomShard
+	// roomsMutex only guards growth of the rooms map itself.
+	roomsMutex     *sync.Mutex
+	sessionStore   sessions.Store
+	broker         broker.Broker
+	overflowPolicy overflowPolicy
+	// subscriberWG tracks every live handleSubscribe call so Shutdown
+	// can wait for them to drain after being asked to disconnect.
+	subscriberWG *sync.WaitGroup
+}
+
+// shardFor returns roomId's shard, creating it on first use.
+func (handler apiHandler) shardFor(roomId string) *roomShard {
+	handler.roomsMutex.Lock()
+	defer handler.roomsMutex.Unlock()
+
+	shard, ok := handler.rooms[roomId]
+	if !ok {
+		shard = &roomShard{subscribers: make(map[*subscriber]struct{})}
+		handler.rooms[roomId] = shard
+	}
+
+	return shard
 }
 
 func (handler apiHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 	handler.router.ServeHTTP(writer, request)
 }
 
-func NewHandler(q *pgstore.Queries) http.Handler {
+// Shutdown closes every live WebSocket subscriber with a "server shutting
+// down" close frame and waits for their goroutines to drain, or for ctx to
+// be done, whichever comes first.
+func (handler apiHandler) Shutdown(ctx context.Context) error {
+	handler.roomsMutex.Lock()
+	shards := make([]*roomShard, 0, len(handler.rooms))
+	for _, shard := range handler.rooms {
+		shards = append(shards, shard)
+	}
+	handler.roomsMutex.Unlock()
+
+	subs := make([]*subscriber, 0)
+	for _, shard := range shards {
+		shard.mutex.RLock()
+		for sub := range shard.subscribers {
+			subs = append(subs, sub)
+		}
+		shard.mutex.RUnlock()
+	}
+
+	// Fan the close frames out concurrently and bound each write by
+	// whichever is tighter, ctx's deadline or writeWait, so a handful of
+	// slow or dead clients can't turn this into numSubscribers*writeWait.
+	var closeWG sync.WaitGroup
+	for _, sub := range subs {
+		closeWG.Add(1)
+		go func(sub *subscriber) {
+			defer closeWG.Done()
+			deadline := time.Now().Add(writeWait)
+			if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+				deadline = ctxDeadline
+			}
+			closeMessage := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+			_ = sub.conn.WriteControl(websocket.CloseMessage, closeMessage, deadline)
+			sub.cancel()
+		}(sub)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		closeWG.Wait()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-ctx.Done():
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		handler.subscriberWG.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// allowedOriginsFromEnv reads ALLOWED_ORIGINS, a comma-separated list of
+// exact frontend origins (e.g. "https://app.example.com"), for the CORS
+// allowlist. AllowCredentials is on, so this must never fall back to a
+// wildcard: go-chi/cors treats a nil/empty AllowedOrigins as "allow all",
+// so an unset ALLOWED_ORIGINS fails closed here instead of silently
+// allowing every origin.
+func allowedOriginsFromEnv() ([]string, error) {
+	raw := os.Getenv("ALLOWED_ORIGINS")
+	if raw == "" {
+		return nil, fmt.Errorf("ALLOWED_ORIGINS must be set")
+	}
+
+	origins := strings.Split(raw, ",")
+	for i, origin := range origins {
+		origins[i] = strings.TrimSpace(origin)
+	}
+	return origins, nil
+}
+
+func NewHandler(q *pgstore.Queries, b broker.Broker) (Handler, error) {
+	sessionStore, err := auth.NewSessionStore()
+	if err != nil {
+		return nil, err
+	}
+
+	allowedOrigins, err := allowedOriginsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
 	api := apiHandler{
 		queries: q,
 		upgrader: websocket.Upgrader{
@@ -37,8 +177,12 @@ func NewHandler(q *pgstore.Queries) http.Handler {
 				return true
 			},
 		},
-		subscribers: make(map[string]map[*websocket.Conn]context.CancelFunc),
-		mutex:       &sync.Mutex{},
+		rooms:          make(map[string]*roomShard),
+		roomsMutex:     &sync.Mutex{},
+		sessionStore:   sessionStore,
+		broker:         b,
+		overflowPolicy: overflowPolicyFromEnv(),
+		subscriberWG:   &sync.WaitGroup{},
 	}
 
 	router := chi.NewRouter()
@@ -46,48 +190,102 @@ func NewHandler(q *pgstore.Queries) http.Handler {
 	router.Use(middleware.RequestID, middleware.Logger, middleware.Recoverer)
 
 	router.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"https://*", "http://*"},
+		AllowedOrigins:   allowedOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
 		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: false,
+		AllowCredentials: true,
 		MaxAge:           300,
 	}))
 
-	router.Get("/subscribe/{room_id}", api.handleSubscribe)
+	requireAuth := auth.RequireAuth(api.sessionStore, api.queries)
 
 	router.Get("/health", api.Health)
 
+	router.Route("/subscribe/{room_id}", func(router chi.Router) {
+		router.Use(requireAuth)
+		router.Get("/", api.handleSubscribe)
+	})
+
 	router.Route("/api", func(router chi.Router) {
+		router.Route("/auth", func(router chi.Router) {
+			router.Post("/register", api.handleRegister)
+			router.Post("/login", api.handleLogin)
+			router.Post("/logout", api.handleLogout)
+		})
+
 		router.Route("/rooms", func(router chi.Router) {
 			router.Post("/", api.handleCreateRoom)
 			router.Get("/", api.handleFetchRooms)
 
 			router.Route("/{room_id}/messages", func(router chi.Router) {
 				router.Get("/", api.handleFetchRoomMessages)
-				router.Post("/", api.handleCreateRoomMessage)
+
+				router.Group(func(router chi.Router) {
+					router.Use(requireAuth)
+					router.Post("/", api.handleCreateRoomMessage)
+				})
 
 				router.Route("/{message_id}", func(router chi.Router) {
 					router.Get("/", api.handleGetRoomMessage)
-					router.Patch("/reaction", api.handleAddMessageReaction)
-					router.Delete("/reaction", api.handleRemoveMessageReaction)
-					router.Patch("/answer", api.handleAnswerMessage)
+
+					router.Group(func(router chi.Router) {
+						router.Use(requireAuth)
+						router.Patch("/reaction", api.handleAddMessageReaction)
+						router.Delete("/reaction", api.handleRemoveMessageReaction)
+						router.Patch("/answer", api.handleAnswerMessage)
+					})
 				})
 			})
 		})
 	})
 
 	api.router = router
-	return api
+	return api, nil
 }
 
 const (
-	KindMessageCreated = "message_created"
+	KindMessageCreated         = "message_created"
+	KindMessageReactionAdded   = "message_reaction_added"
+	KindMessageReactionRemoved = "message_reaction_removed"
+	KindMessageAnswered        = "message_answered"
 )
 
 type MessageCreatedValue struct {
 	Id      string `json:"id"`
 	Message string `json:"message"`
+	Author  string `json:"author,omitempty"`
+}
+
+type MessageReactionValue struct {
+	MessageId string `json:"message_id"`
+	Count     int64  `json:"count"`
+}
+
+type MessageAnsweredValue struct {
+	MessageId string `json:"message_id"`
+}
+
+// MessageResponse is the JSON shape returned for a single message by the
+// REST endpoints (as opposed to the lighter values broadcast over the
+// websocket). Author is omitted when the message predates per-user
+// identity (no user_id).
+type MessageResponse struct {
+	Id            string `json:"id"`
+	Message       string `json:"message"`
+	ReactionCount int64  `json:"reaction_count"`
+	Answered      bool   `json:"answered"`
+	Author        string `json:"author,omitempty"`
+}
+
+func newMessageResponse(id uuid.UUID, message string, reactionCount int64, answered bool, username pgtype.Text) MessageResponse {
+	return MessageResponse{
+		Id:            id.String(),
+		Message:       message,
+		ReactionCount: reactionCount,
+		Answered:      answered,
+		Author:        username.String,
+	}
 }
 
 type Message struct {
@@ -96,22 +294,81 @@ type Message struct {
 	Value  any    `json:"value"`
 }
 
+// notifyClients no longer writes to websocket connections directly: it
+// publishes through the broker, and every process (including this one)
+// re-fans the message out to its own local subscribers via fanOutRoom.
 func (handler apiHandler) notifyClients(message Message) {
-	handler.mutex.Lock()
-	defer handler.mutex.Unlock()
+	err := handler.broker.Publish(context.Background(), broker.Message{
+		RoomId: message.RoomId,
+		Kind:   message.Kind,
+		Value:  message.Value,
+	})
+
+	if err != nil {
+		slog.Error("Failed to publish message", "error", err.Error(), "room_id", message.RoomId)
+	}
+}
+
+// ensureRoomFanout makes sure this process has a single broker subscription
+// open for roomId, started lazily on its first local subscriber. The
+// subscribe call (a network round trip against the broker, e.g. a pgx
+// Acquire+LISTEN when BROKER=pg) runs without holding shard.mutex so it
+// never stalls this room's subscribe/unsubscribe/broadcast traffic.
+func (handler apiHandler) ensureRoomFanout(roomId string) {
+	shard := handler.shardFor(roomId)
+
+	shard.mutex.Lock()
+	if shard.unsubscribe != nil {
+		shard.mutex.Unlock()
+		return
+	}
+	shard.mutex.Unlock()
+
+	messages, unsubscribe, err := handler.broker.Subscribe(context.Background(), roomId)
+	if err != nil {
+		slog.Error("Failed to subscribe to broker", "error", err.Error(), "room_id", roomId)
+		return
+	}
+
+	shard.mutex.Lock()
+	if shard.unsubscribe != nil {
+		// Another goroutine already started the fan-out while this one
+		// was subscribing; drop the redundant subscription.
+		shard.mutex.Unlock()
+		unsubscribe()
+		return
+	}
+	shard.unsubscribe = unsubscribe
+	shard.mutex.Unlock()
+
+	go handler.fanOutRoom(shard, messages)
+}
 
-	subscribers, ok := handler.subscribers[message.RoomId]
+// releaseRoomFanout tears down the broker subscription for roomId once its
+// last local subscriber has gone away.
+func (handler apiHandler) releaseRoomFanout(roomId string) {
+	shard := handler.shardFor(roomId)
 
-	if !ok || len(subscribers) == 0 {
-		slog.Info("No subscribers to notify", "room_id", message.RoomId)
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+
+	if len(shard.subscribers) > 0 {
 		return
 	}
 
-	for conn, cancel := range subscribers {
-		if err := conn.WriteJSON(message); err != nil {
-			slog.Error("Failed to send message", "error", err.Error())
-			cancel()
+	if shard.unsubscribe != nil {
+		shard.unsubscribe()
+		shard.unsubscribe = nil
+	}
+}
+
+func (handler apiHandler) fanOutRoom(shard *roomShard, messages <-chan broker.Message) {
+	for message := range messages {
+		shard.mutex.RLock()
+		for sub := range shard.subscribers {
+			sub.deliver(Message{RoomId: message.RoomId, Kind: message.Kind, Value: message.Value})
 		}
+		shard.mutex.RUnlock()
 	}
 }
 
@@ -146,27 +403,43 @@ func (handler apiHandler) handleSubscribe(writer http.ResponseWriter, request *h
 
 	defer conn.Close()
 
+	handler.subscriberWG.Add(1)
+	defer handler.subscriberWG.Done()
+
 	ctx, cancel := context.WithCancel(request.Context())
+	sub := newSubscriber(conn, cancel, handler.overflowPolicy)
 
-	handler.mutex.Lock()
+	shard := handler.shardFor(rawRoomId)
 
-	if _, ok := handler.subscribers[rawRoomId]; !ok {
-		handler.subscribers[rawRoomId] = make(map[*websocket.Conn]context.CancelFunc)
-	}
+	shard.mutex.Lock()
 
 	slog.Info("New subscriber", "room_id", rawRoomId, "conn", conn.RemoteAddr().String())
 
-	handler.subscribers[rawRoomId][conn] = cancel
+	shard.subscribers[sub] = struct{}{}
 
-	handler.mutex.Unlock()
+	shard.mutex.Unlock()
+
+	handler.ensureRoomFanout(rawRoomId)
+
+	go sub.writeLoop(ctx)
+	go sub.readLoop()
 
 	<-ctx.Done()
 
-	handler.mutex.Lock()
+	shard.mutex.Lock()
+
+	delete(shard.subscribers, sub)
 
-	delete(handler.subscribers[rawRoomId], conn)
+	shard.mutex.Unlock()
 
-	handler.mutex.Unlock()
+	handler.releaseRoomFanout(rawRoomId)
+
+	// writeLoop may still be mid-write (a queued message or a ping);
+	// WriteControl, unlike WriteMessage, is safe to call concurrently with
+	// it, which is why close frames, here and in Shutdown, go through
+	// WriteControl rather than the writer goroutine.
+	closeMessage := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	_ = conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(writeWait))
 }
 
 func (handler apiHandler) Health(writer http.ResponseWriter, request *http.Request) {
@@ -209,9 +482,97 @@ func (handler apiHandler) handleCreateRoom(writer http.ResponseWriter, request *
 	_, _ = writer.Write(response)
 }
 
-func (handler apiHandler) handleFetchRooms(writer http.ResponseWriter, request *http.Request) {}
+func (handler apiHandler) handleFetchRooms(writer http.ResponseWriter, request *http.Request) {
+	rooms, err := handler.queries.GetRooms(request.Context())
+
+	if err != nil {
+		slog.Warn("Failed to fetch rooms", "error", err.Error())
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	type RoomResponse struct {
+		Id    string `json:"id"`
+		Theme string `json:"theme"`
+	}
+
+	response := make([]RoomResponse, len(rooms))
+	for i, room := range rooms {
+		response[i] = RoomResponse{Id: room.ID.String(), Theme: room.Theme}
+	}
+
+	body, _ := json.Marshal(response)
+	writer.Header().Set("Content-Type", "application/json")
+	_, _ = writer.Write(body)
+}
 
 func (handler apiHandler) handleFetchRoomMessages(writer http.ResponseWriter, request *http.Request) {
+	rawRoomId := chi.URLParam(request, "room_id")
+	roomId, err := uuid.Parse(rawRoomId)
+
+	if err != nil {
+		http.Error(writer, "Invalid room id", http.StatusBadRequest)
+		return
+	}
+
+	_, err = handler.queries.GetRoom(request.Context(), roomId)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(writer, "room not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	limit := defaultMessagesPageLimit
+	if rawLimit := request.URL.Query().Get("limit"); rawLimit != "" {
+		parsed, err := strconv.Atoi(rawLimit)
+		if err != nil || parsed <= 0 || parsed > maxMessagesPageLimit {
+			http.Error(writer, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	cursor, err := decodeMessageCursor(request.URL.Query().Get("cursor"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	messages, err := handler.queries.GetRoomMessages(request.Context(), pgstore.GetRoomMessagesParams{
+		RoomID:         roomId,
+		AfterCreatedAt: cursor.CreatedAt,
+		AfterID:        cursor.ID,
+		Limit:          int32(limit),
+	})
+
+	if err != nil {
+		slog.Warn("Failed to fetch room messages", "error", err.Error())
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	type ResponseSchema struct {
+		Messages   []MessageResponse `json:"messages"`
+		NextCursor string            `json:"next_cursor,omitempty"`
+	}
+
+	response := ResponseSchema{Messages: make([]MessageResponse, len(messages))}
+	for i, message := range messages {
+		response.Messages[i] = newMessageResponse(message.ID, message.Message, message.ReactionCount, message.Answered, message.Username)
+	}
+	if len(messages) == limit {
+		last := messages[len(messages)-1]
+		response.NextCursor = encodeMessageCursor(last.CreatedAt, last.ID)
+	}
+
+	body, _ := json.Marshal(response)
+	writer.Header().Set("Content-Type", "application/json")
+	_, _ = writer.Write(body)
 }
 
 func (handler apiHandler) handleCreateRoomMessage(writer http.ResponseWriter, request *http.Request) {
@@ -235,6 +596,12 @@ func (handler apiHandler) handleCreateRoomMessage(writer http.ResponseWriter, re
 		return
 	}
 
+	user, ok := auth.UserFromContext(request.Context())
+	if !ok {
+		http.Error(writer, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	type bodySchema struct {
 		Message string `json:"message"`
 	}
@@ -244,7 +611,17 @@ func (handler apiHandler) handleCreateRoomMessage(writer http.ResponseWriter, re
 		return
 	}
 
-	messageId, err := handler.queries.InsertMessage(request.Context(), pgstore.InsertMessageParams{RoomID: roomId, Message: body.Message})
+	userId, err := uuid.Parse(user.ID)
+	if err != nil {
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	messageId, err := handler.queries.InsertMessage(request.Context(), pgstore.InsertMessageParams{
+		RoomID:  roomId,
+		Message: body.Message,
+		UserID:  uuid.NullUUID{UUID: userId, Valid: true},
+	})
 
 	if err != nil {
 		slog.Warn("Failed to create message", "error", err.Error())
@@ -266,16 +643,368 @@ func (handler apiHandler) handleCreateRoomMessage(writer http.ResponseWriter, re
 		Value: MessageCreatedValue{
 			Id:      messageId.String(),
 			Message: body.Message,
+			Author:  user.Username,
 		},
 	})
 }
 
-func (handler apiHandler) handleGetRoomMessage(writer http.ResponseWriter, request *http.Request) {}
+func (handler apiHandler) handleGetRoomMessage(writer http.ResponseWriter, request *http.Request) {
+	rawRoomId := chi.URLParam(request, "room_id")
+	roomId, err := uuid.Parse(rawRoomId)
+
+	if err != nil {
+		http.Error(writer, "Invalid room id", http.StatusBadRequest)
+		return
+	}
+
+	rawMessageId := chi.URLParam(request, "message_id")
+	messageId, err := uuid.Parse(rawMessageId)
+
+	if err != nil {
+		http.Error(writer, "Invalid message id", http.StatusBadRequest)
+		return
+	}
+
+	message, err := handler.queries.GetMessage(request.Context(), messageId)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(writer, "message not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	if message.RoomID != roomId {
+		http.Error(writer, "message not found", http.StatusNotFound)
+		return
+	}
+
+	response, _ := json.Marshal(newMessageResponse(message.ID, message.Message, message.ReactionCount, message.Answered, message.Username))
+	writer.Header().Set("Content-Type", "application/json")
+	_, _ = writer.Write(response)
+}
 
 func (handler apiHandler) handleAddMessageReaction(writer http.ResponseWriter, request *http.Request) {
+	rawRoomId := chi.URLParam(request, "room_id")
+	roomId, err := uuid.Parse(rawRoomId)
+
+	if err != nil {
+		http.Error(writer, "Invalid room id", http.StatusBadRequest)
+		return
+	}
+
+	rawMessageId := chi.URLParam(request, "message_id")
+	messageId, err := uuid.Parse(rawMessageId)
+
+	if err != nil {
+		http.Error(writer, "Invalid message id", http.StatusBadRequest)
+		return
+	}
+
+	user, ok := auth.UserFromContext(request.Context())
+	if !ok {
+		http.Error(writer, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	userId, err := uuid.Parse(user.ID)
+	if err != nil {
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	message, err := handler.queries.GetMessage(request.Context(), messageId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(writer, "message not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	if message.RoomID != roomId {
+		http.Error(writer, "message not found", http.StatusNotFound)
+		return
+	}
+
+	count := message.ReactionCount
+
+	_, err = handler.queries.InsertMessageReaction(request.Context(), pgstore.InsertMessageReactionParams{
+		MessageID: messageId,
+		UserID:    userId,
+	})
+	switch {
+	case err == nil:
+		count, err = handler.queries.ReactToMessage(request.Context(), messageId)
+		if err != nil {
+			slog.Warn("Failed to react to message", "error", err.Error())
+			http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+			return
+		}
+	case errors.Is(err, pgx.ErrNoRows):
+		// Already reacted: idempotent no-op, the current count stands.
+	default:
+		slog.Warn("Failed to record reaction", "error", err.Error())
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	type ResponseSchema struct {
+		Count int64 `json:"count"`
+	}
+
+	response, _ := json.Marshal(ResponseSchema{Count: count})
+	writer.Header().Set("Content-Type", "application/json")
+	_, _ = writer.Write(response)
+
+	go handler.notifyClients(Message{
+		Kind:   KindMessageReactionAdded,
+		RoomId: rawRoomId,
+		Value:  MessageReactionValue{MessageId: rawMessageId, Count: count},
+	})
 }
 
 func (handler apiHandler) handleRemoveMessageReaction(writer http.ResponseWriter, request *http.Request) {
+	rawRoomId := chi.URLParam(request, "room_id")
+	roomId, err := uuid.Parse(rawRoomId)
+
+	if err != nil {
+		http.Error(writer, "Invalid room id", http.StatusBadRequest)
+		return
+	}
+
+	rawMessageId := chi.URLParam(request, "message_id")
+	messageId, err := uuid.Parse(rawMessageId)
+
+	if err != nil {
+		http.Error(writer, "Invalid message id", http.StatusBadRequest)
+		return
+	}
+
+	user, ok := auth.UserFromContext(request.Context())
+	if !ok {
+		http.Error(writer, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	userId, err := uuid.Parse(user.ID)
+	if err != nil {
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	message, err := handler.queries.GetMessage(request.Context(), messageId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(writer, "message not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	if message.RoomID != roomId {
+		http.Error(writer, "message not found", http.StatusNotFound)
+		return
+	}
+
+	count := message.ReactionCount
+
+	_, err = handler.queries.DeleteMessageReaction(request.Context(), pgstore.DeleteMessageReactionParams{
+		MessageID: messageId,
+		UserID:    userId,
+	})
+	switch {
+	case err == nil:
+		count, err = handler.queries.RemoveReactionFromMessage(request.Context(), messageId)
+		if err != nil {
+			slog.Warn("Failed to remove reaction from message", "error", err.Error())
+			http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+			return
+		}
+	case errors.Is(err, pgx.ErrNoRows):
+		// Hadn't reacted: idempotent no-op, the current count stands.
+	default:
+		slog.Warn("Failed to remove reaction", "error", err.Error())
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	type ResponseSchema struct {
+		Count int64 `json:"count"`
+	}
+
+	response, _ := json.Marshal(ResponseSchema{Count: count})
+	writer.Header().Set("Content-Type", "application/json")
+	_, _ = writer.Write(response)
+
+	go handler.notifyClients(Message{
+		Kind:   KindMessageReactionRemoved,
+		RoomId: rawRoomId,
+		Value:  MessageReactionValue{MessageId: rawMessageId, Count: count},
+	})
 }
 
-func (handler apiHandler) handleAnswerMessage(writer http.ResponseWriter, request *http.Request) {}
+func (handler apiHandler) handleAnswerMessage(writer http.ResponseWriter, request *http.Request) {
+	rawRoomId := chi.URLParam(request, "room_id")
+	roomId, err := uuid.Parse(rawRoomId)
+
+	if err != nil {
+		http.Error(writer, "Invalid room id", http.StatusBadRequest)
+		return
+	}
+
+	rawMessageId := chi.URLParam(request, "message_id")
+	messageId, err := uuid.Parse(rawMessageId)
+
+	if err != nil {
+		http.Error(writer, "Invalid message id", http.StatusBadRequest)
+		return
+	}
+
+	message, err := handler.queries.GetMessage(request.Context(), messageId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(writer, "message not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	if message.RoomID != roomId {
+		http.Error(writer, "message not found", http.StatusNotFound)
+		return
+	}
+
+	if err := handler.queries.MarkMessageAsAnswered(request.Context(), messageId); err != nil {
+		slog.Warn("Failed to mark message as answered", "error", err.Error())
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+
+	go handler.notifyClients(Message{
+		Kind:   KindMessageAnswered,
+		RoomId: rawRoomId,
+		Value:  MessageAnsweredValue{MessageId: rawMessageId},
+	})
+}
+
+func (handler apiHandler) handleRegister(writer http.ResponseWriter, request *http.Request) {
+	type bodySchema struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	var body bodySchema
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if body.Username == "" || body.Password == "" {
+		http.Error(writer, "username and password are required", http.StatusBadRequest)
+		return
+	}
+
+	passwordHash, err := auth.HashPassword(body.Password)
+	if err != nil {
+		slog.Error("Failed to hash password", "error", err.Error())
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	userId, err := handler.queries.InsertUser(request.Context(), pgstore.InsertUserParams{
+		Username:     body.Username,
+		PasswordHash: passwordHash,
+	})
+
+	if err != nil {
+		slog.Warn("Failed to create user", "error", err.Error())
+		http.Error(writer, "username already taken", http.StatusConflict)
+		return
+	}
+
+	type ResponseSchema struct {
+		UserId string `json:"user_id"`
+	}
+
+	response, _ := json.Marshal(ResponseSchema{UserId: userId.String()})
+	writer.Header().Set("Content-Type", "application/json")
+	_, _ = writer.Write(response)
+}
+
+func (handler apiHandler) handleLogin(writer http.ResponseWriter, request *http.Request) {
+	type bodySchema struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	var body bodySchema
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	record, err := handler.queries.GetUserByUsername(request.Context(), body.Username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(writer, "invalid credentials", http.StatusUnauthorized)
+			return
+		}
+
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	if err := auth.CheckPassword(record.PasswordHash, body.Password); err != nil {
+		http.Error(writer, "invalid credentials", http.StatusUnauthorized)
+		return
+	}
+
+	session, err := handler.sessionStore.Get(request, auth.SessionName)
+	if err != nil {
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	session.Values[auth.SessionUserIDKey] = record.ID.String()
+	if err := session.Save(request, writer); err != nil {
+		slog.Error("Failed to save session", "error", err.Error())
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	type ResponseSchema struct {
+		UserId   string `json:"user_id"`
+		Username string `json:"username"`
+	}
+
+	response, _ := json.Marshal(ResponseSchema{UserId: record.ID.String(), Username: record.Username})
+	writer.Header().Set("Content-Type", "application/json")
+	_, _ = writer.Write(response)
+}
+
+func (handler apiHandler) handleLogout(writer http.ResponseWriter, request *http.Request) {
+	session, err := handler.sessionStore.Get(request, auth.SessionName)
+	if err != nil {
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	session.Options.MaxAge = -1
+	if err := session.Save(request, writer); err != nil {
+		slog.Error("Failed to clear session", "error", err.Error())
+		http.Error(writer, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
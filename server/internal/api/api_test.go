@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestHandler() apiHandler {
+	return apiHandler{
+		rooms:        make(map[string]*roomShard),
+		roomsMutex:   &sync.Mutex{},
+		subscriberWG: &sync.WaitGroup{},
+	}
+}
+
+func TestShutdown_ReturnsOnceSubscribersDrain(t *testing.T) {
+	handler := newTestHandler()
+	handler.subscriberWG.Add(1)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		handler.subscriberWG.Done()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := handler.Shutdown(ctx); err != nil {
+		t.Fatalf("expected Shutdown to succeed once subscribers drain, got %v", err)
+	}
+}
+
+func TestShutdown_TimesOutWhenSubscribersNeverDrain(t *testing.T) {
+	handler := newTestHandler()
+	handler.subscriberWG.Add(1)
+	defer handler.subscriberWG.Done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := handler.Shutdown(ctx)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
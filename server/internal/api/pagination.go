@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMessagesPageLimit = 20
+	// maxMessagesPageLimit bounds ?limit= so it always fits the int32
+	// Postgres LIMIT param; anything caught by strconv.Atoi but too large
+	// for that cast would otherwise wrap into an arbitrary value.
+	maxMessagesPageLimit = math.MaxInt32
+)
+
+// messageCursor is the keyset position used to page through a room's
+// messages in (created_at, id) order.
+type messageCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// encodeMessageCursor returns the opaque cursor for the given message,
+// suitable for returning to clients as next_cursor.
+func encodeMessageCursor(createdAt time.Time, id uuid.UUID) string {
+	raw := fmt.Sprintf("%s|%s", createdAt.Format(time.RFC3339Nano), id.String())
+	return base64.URLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeMessageCursor parses a cursor produced by encodeMessageCursor. An
+// empty string decodes to the zero cursor, which sorts before every row.
+func decodeMessageCursor(raw string) (messageCursor, error) {
+	if raw == "" {
+		return messageCursor{}, nil
+	}
+
+	decoded, err := base64.URLEncoding.DecodeString(raw)
+	if err != nil {
+		return messageCursor{}, fmt.Errorf("invalid cursor")
+	}
+
+	createdAtRaw, idRaw, ok := strings.Cut(string(decoded), "|")
+	if !ok {
+		return messageCursor{}, fmt.Errorf("invalid cursor")
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtRaw)
+	if err != nil {
+		return messageCursor{}, fmt.Errorf("invalid cursor")
+	}
+
+	id, err := uuid.Parse(idRaw)
+	if err != nil {
+		return messageCursor{}, fmt.Errorf("invalid cursor")
+	}
+
+	return messageCursor{CreatedAt: createdAt, ID: id}, nil
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func encodeRawCursor(raw string) string {
+	return base64.URLEncoding.EncodeToString([]byte(raw))
+}
+
+func TestMessageCursor_RoundTrip(t *testing.T) {
+	want := messageCursor{CreatedAt: time.Now().UTC(), ID: uuid.New()}
+
+	encoded := encodeMessageCursor(want.CreatedAt, want.ID)
+	got, err := decodeMessageCursor(encoded)
+	if err != nil {
+		t.Fatalf("decodeMessageCursor(%q): unexpected error: %v", encoded, err)
+	}
+
+	if !got.CreatedAt.Equal(want.CreatedAt) || got.ID != want.ID {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeMessageCursor_EmptyStringIsZeroCursor(t *testing.T) {
+	got, err := decodeMessageCursor("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != (messageCursor{}) {
+		t.Fatalf("expected zero cursor, got %+v", got)
+	}
+}
+
+func TestDecodeMessageCursor_RejectsInvalidInput(t *testing.T) {
+	cases := map[string]string{
+		"not valid base64":  "not-valid-base64!!!",
+		"missing separator": encodeRawCursor("2024-01-01T00:00:00Z" + uuid.New().String()),
+		"bad timestamp":     encodeRawCursor("not-a-time|" + uuid.New().String()),
+		"bad uuid":          encodeRawCursor(time.Now().Format(time.RFC3339Nano) + "|not-a-uuid"),
+	}
+
+	for name, raw := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := decodeMessageCursor(raw); err == nil {
+				t.Fatalf("expected an error for %q", raw)
+			}
+		})
+	}
+}
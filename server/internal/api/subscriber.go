@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingInterval   = 30 * time.Second
+	sendBufferSize = 16
+)
+
+type overflowPolicy int
+
+const (
+	overflowDropOldest overflowPolicy = iota
+	overflowDisconnect
+)
+
+// overflowPolicyFromEnv reads SUBSCRIBER_OVERFLOW_POLICY ("drop_oldest" or
+// "disconnect"), defaulting to drop-oldest so a slow client loses history
+// instead of taking the rest of the room down with it.
+func overflowPolicyFromEnv() overflowPolicy {
+	if os.Getenv("SUBSCRIBER_OVERFLOW_POLICY") == "disconnect" {
+		return overflowDisconnect
+	}
+	return overflowDropOldest
+}
+
+// subscriber owns one websocket connection's lifecycle: a buffered send
+// queue, a writer goroutine that is the only goroutine sending data and
+// ping frames on conn (close frames go out through WriteControl, which may
+// run concurrently with it), and a reader goroutine that keeps the read deadline alive off pong
+// frames. Cancelling ctx (via cancel) is the only way to tear it down.
+type subscriber struct {
+	conn   *websocket.Conn
+	send   chan Message
+	cancel context.CancelFunc
+	policy overflowPolicy
+}
+
+func newSubscriber(conn *websocket.Conn, cancel context.CancelFunc, policy overflowPolicy) *subscriber {
+	return &subscriber{
+		conn:   conn,
+		send:   make(chan Message, sendBufferSize),
+		cancel: cancel,
+		policy: policy,
+	}
+}
+
+// deliver enqueues message for the writer goroutine, applying the
+// subscriber's overflow policy when the send buffer is full.
+func (sub *subscriber) deliver(message Message) {
+	select {
+	case sub.send <- message:
+		return
+	default:
+	}
+
+	switch sub.policy {
+	case overflowDisconnect:
+		sub.cancel()
+	default: // drop-oldest
+		select {
+		case <-sub.send:
+		default:
+		}
+		select {
+		case sub.send <- message:
+		default:
+		}
+	}
+}
+
+// writeLoop is the only goroutine that sends data and ping frames on
+// sub.conn (close frames use WriteControl elsewhere): queued messages and
+// periodic keepalive pings share it so a write deadline
+// always bounds how long a slow client can stall it.
+func (sub *subscriber) writeLoop(ctx context.Context) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	defer sub.cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case message, ok := <-sub.send:
+			if !ok {
+				return
+			}
+			sub.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := sub.conn.WriteJSON(message); err != nil {
+				slog.Error("Failed to send message", "error", err.Error())
+				return
+			}
+
+		case <-ticker.C:
+			sub.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := sub.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				slog.Warn("Failed to ping subscriber", "error", err.Error())
+				return
+			}
+		}
+	}
+}
+
+// readLoop keeps the read deadline alive off pong frames and is how a dead
+// TCP connection (or a client closing cleanly) gets detected; the protocol
+// never expects the client to send application messages.
+func (sub *subscriber) readLoop() {
+	defer sub.cancel()
+
+	sub.conn.SetReadDeadline(time.Now().Add(pongWait))
+	sub.conn.SetPongHandler(func(string) error {
+		sub.conn.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
+	for {
+		if _, _, err := sub.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
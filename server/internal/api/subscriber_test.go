@@ -0,0 +1,45 @@
+package api
+
+import "testing"
+
+func TestDeliver_DropOldestKeepsNewestWhenFull(t *testing.T) {
+	sub := &subscriber{
+		send:   make(chan Message, 2),
+		cancel: func() {},
+		policy: overflowDropOldest,
+	}
+
+	sub.deliver(Message{Kind: "first"})
+	sub.deliver(Message{Kind: "second"})
+	sub.deliver(Message{Kind: "third"})
+
+	if got := (<-sub.send).Kind; got != "second" {
+		t.Fatalf("expected oldest message to be dropped, got %q first in queue", got)
+	}
+	if got := (<-sub.send).Kind; got != "third" {
+		t.Fatalf("expected newest message to survive, got %q", got)
+	}
+}
+
+func TestDeliver_DisconnectCancelsWhenFull(t *testing.T) {
+	canceled := false
+	sub := &subscriber{
+		send:   make(chan Message, 1),
+		cancel: func() { canceled = true },
+		policy: overflowDisconnect,
+	}
+
+	sub.deliver(Message{Kind: "first"})
+	if canceled {
+		t.Fatalf("cancel should not fire while the buffer still has room")
+	}
+
+	sub.deliver(Message{Kind: "second"})
+	if !canceled {
+		t.Fatalf("expected cancel to be called once the buffer is full")
+	}
+
+	if got := (<-sub.send).Kind; got != "first" {
+		t.Fatalf("expected the queued message to be left untouched, got %q", got)
+	}
+}
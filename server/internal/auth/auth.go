@@ -0,0 +1,47 @@
+// Package auth provides session-backed authentication shared by the API
+// handlers: password hashing, the cookie session store, and the request
+// context plumbing used to carry the authenticated user.
+package auth
+
+import (
+	"context"
+
+	"github.com/CassianoJunior/go-react/internal/store/pgstore"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type contextKey int
+
+const userContextKey contextKey = iota
+
+// User is the authenticated identity attached to a request's context.
+type User struct {
+	ID       string
+	Username string
+}
+
+func ContextWithUser(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, userContextKey, user)
+}
+
+// UserFromContext returns the authenticated user stored on ctx, if any.
+func UserFromContext(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(userContextKey).(User)
+	return user, ok
+}
+
+func UserFromRecord(record pgstore.User) User {
+	return User{ID: record.ID.String(), Username: record.Username}
+}
+
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func CheckPassword(hash, password string) error {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/CassianoJunior/go-react/internal/store/pgstore"
+	"github.com/google/uuid"
+	"github.com/gorilla/sessions"
+)
+
+// RequireAuth loads the session cookie, resolves it to a pgstore.User and
+// attaches the corresponding User to the request context. Requests without a valid session are
+// rejected with 401 before reaching the wrapped handler.
+func RequireAuth(store sessions.Store, queries *pgstore.Queries) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			session, err := store.Get(request, SessionName)
+			if err != nil {
+				http.Error(writer, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			rawUserID, ok := session.Values[SessionUserIDKey].(string)
+			if !ok {
+				http.Error(writer, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			userID, err := uuid.Parse(rawUserID)
+			if err != nil {
+				http.Error(writer, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			record, err := queries.GetUserByID(request.Context(), userID)
+			if err != nil {
+				http.Error(writer, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			ctx := ContextWithUser(request.Context(), UserFromRecord(record))
+			next.ServeHTTP(writer, request.WithContext(ctx))
+		})
+	}
+}
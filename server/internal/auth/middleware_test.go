@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CassianoJunior/go-react/internal/store/pgstore"
+	"github.com/google/uuid"
+	"github.com/gorilla/sessions"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeRow is a pgx.Row that scans back a fixed set of values, in order.
+type fakeRow struct {
+	values []any
+	err    error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	for i, d := range dest {
+		switch v := d.(type) {
+		case *uuid.UUID:
+			*v = r.values[i].(uuid.UUID)
+		case *string:
+			*v = r.values[i].(string)
+		}
+	}
+	return nil
+}
+
+// fakeDBTX backs a *pgstore.Queries with canned QueryRow results so
+// RequireAuth can be tested without a database.
+type fakeDBTX struct {
+	row fakeRow
+}
+
+func (f fakeDBTX) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+
+func (f fakeDBTX) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return nil, nil
+}
+
+func (f fakeDBTX) QueryRow(context.Context, string, ...any) pgx.Row {
+	return f.row
+}
+
+func newTestSessionStore(t *testing.T) sessions.Store {
+	t.Helper()
+	return sessions.NewCookieStore([]byte("test-secret"))
+}
+
+// sessionCookie signs a session carrying userID and returns the cookie
+// set for it.
+func sessionCookie(t *testing.T, store sessions.Store, userID string) *http.Cookie {
+	t.Helper()
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	recorder := httptest.NewRecorder()
+
+	session, err := store.Get(request, SessionName)
+	if err != nil {
+		t.Fatalf("Get session: %v", err)
+	}
+	session.Values[SessionUserIDKey] = userID
+	if err := session.Save(request, recorder); err != nil {
+		t.Fatalf("Save session: %v", err)
+	}
+
+	cookies := recorder.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("expected exactly one cookie, got %d", len(cookies))
+	}
+	return cookies[0]
+}
+
+func TestRequireAuth_NoSessionCookie(t *testing.T) {
+	store := newTestSessionStore(t)
+	queries := pgstore.New(fakeDBTX{})
+
+	var called bool
+	handler := RequireAuth(store, queries)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		called = true
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	if called {
+		t.Fatal("next handler should not run without a session cookie")
+	}
+	if recorder.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", recorder.Code)
+	}
+}
+
+func TestRequireAuth_InvalidUserID(t *testing.T) {
+	store := newTestSessionStore(t)
+	queries := pgstore.New(fakeDBTX{})
+
+	handler := RequireAuth(store, queries)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Fatal("next handler should not run for an invalid user id")
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.AddCookie(sessionCookie(t, store, "not-a-uuid"))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", recorder.Code)
+	}
+}
+
+func TestRequireAuth_ValidSession(t *testing.T) {
+	store := newTestSessionStore(t)
+	userID := uuid.New()
+	queries := pgstore.New(fakeDBTX{row: fakeRow{values: []any{userID, "alice", "hash"}}})
+
+	var gotUser User
+	var gotOK bool
+	handler := RequireAuth(store, queries)(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		gotUser, gotOK = UserFromContext(request.Context())
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.AddCookie(sessionCookie(t, store, userID.String()))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", recorder.Code)
+	}
+	if !gotOK {
+		t.Fatal("expected a user to be attached to the request context")
+	}
+	if gotUser.ID != userID.String() || gotUser.Username != "alice" {
+		t.Fatalf("unexpected user on context: %+v", gotUser)
+	}
+}
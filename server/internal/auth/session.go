@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/gorilla/sessions"
+)
+
+const (
+	// SessionName is the cookie name used for the authenticated session.
+	SessionName = "go_react_session"
+	// SessionUserIDKey is the session value holding the authenticated user's id.
+	SessionUserIDKey = "user_id"
+)
+
+// NewSessionStore builds the cookie store backing sessions, keyed off the
+// SESSION_SECRET env var so the signing key never lives in source.
+func NewSessionStore() (*sessions.CookieStore, error) {
+	secret := os.Getenv("SESSION_SECRET")
+	if secret == "" {
+		return nil, fmt.Errorf("SESSION_SECRET must be set")
+	}
+
+	store := sessions.NewCookieStore([]byte(secret))
+	store.Options = &sessions.Options{
+		Path:     "/",
+		MaxAge:   7 * 24 * 60 * 60,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	}
+
+	return store, nil
+}
@@ -0,0 +1,27 @@
+// Package broker abstracts the fan-out of room messages so that a single
+// in-process map (fine for one API instance) and a Postgres LISTEN/NOTIFY
+// relay (needed once there is more than one) can sit behind the same
+// interface.
+package broker
+
+import "context"
+
+// Message carries the same fields as api.Message; unlike api.Message, it
+// also serializes RoomId.
+type Message struct {
+	RoomId string `json:"room_id"`
+	Kind   string `json:"kind"`
+	Value  any    `json:"value"`
+}
+
+// Broker fans messages published for a room out to every subscriber of
+// that room, whether they are local to this process or not.
+type Broker interface {
+	// Publish delivers message to every current subscriber of message.RoomId.
+	Publish(ctx context.Context, message Message) error
+	// Subscribe returns a channel receiving every message published for
+	// roomId from now on, and an unsubscribe func to stop receiving and
+	// release the underlying resources. The channel is closed once
+	// unsubscribe has been called.
+	Subscribe(ctx context.Context, roomId string) (<-chan Message, func(), error)
+}
@@ -0,0 +1,18 @@
+package broker
+
+import (
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// New builds the Broker selected by the BROKER env var ("memory" or "pg"),
+// defaulting to "memory" for single-instance deployments.
+func New(pool *pgxpool.Pool) Broker {
+	switch os.Getenv("BROKER") {
+	case "pg":
+		return NewPgBroker(pool)
+	default:
+		return NewMemoryBroker()
+	}
+}
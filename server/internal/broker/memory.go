@@ -0,0 +1,75 @@
+package broker
+
+import (
+	"context"
+	"sync"
+)
+
+// roomShard holds the subscriber channels for a single room behind their
+// own lock, so publishing to one room never blocks a subscribe/unsubscribe
+// happening on another.
+type roomShard struct {
+	mutex       sync.RWMutex
+	subscribers map[chan Message]struct{}
+}
+
+// MemoryBroker fans messages out to subscribers within this process only.
+// It is the default broker and matches the behavior the API had before
+// multi-instance fan-out existed.
+type MemoryBroker struct {
+	shardsMutex sync.Mutex // only guards growth of the shards map itself
+	shards      map[string]*roomShard
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{shards: make(map[string]*roomShard)}
+}
+
+func (b *MemoryBroker) shardFor(roomId string) *roomShard {
+	b.shardsMutex.Lock()
+	defer b.shardsMutex.Unlock()
+
+	shard, ok := b.shards[roomId]
+	if !ok {
+		shard = &roomShard{subscribers: make(map[chan Message]struct{})}
+		b.shards[roomId] = shard
+	}
+
+	return shard
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, message Message) error {
+	shard := b.shardFor(message.RoomId)
+
+	shard.mutex.RLock()
+	defer shard.mutex.RUnlock()
+
+	for subscriber := range shard.subscribers {
+		select {
+		case subscriber <- message:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (b *MemoryBroker) Subscribe(ctx context.Context, roomId string) (<-chan Message, func(), error) {
+	shard := b.shardFor(roomId)
+
+	messages := make(chan Message, 16)
+
+	shard.mutex.Lock()
+	shard.subscribers[messages] = struct{}{}
+	shard.mutex.Unlock()
+
+	unsubscribe := func() {
+		shard.mutex.Lock()
+		delete(shard.subscribers, messages)
+		shard.mutex.Unlock()
+		close(messages)
+	}
+
+	return messages, unsubscribe, nil
+}
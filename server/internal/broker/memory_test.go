@@ -0,0 +1,79 @@
+package broker
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryBroker_FansOutToEverySubscriberOfTheSameRoom(t *testing.T) {
+	b := NewMemoryBroker()
+
+	messagesA, unsubscribeA, err := b.Subscribe(context.Background(), "room-1")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer unsubscribeA()
+
+	messagesB, unsubscribeB, err := b.Subscribe(context.Background(), "room-1")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer unsubscribeB()
+
+	want := Message{RoomId: "room-1", Kind: "message_created", Value: "hello"}
+	if err := b.Publish(context.Background(), want); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	for _, ch := range []<-chan Message{messagesA, messagesB} {
+		select {
+		case got := <-ch:
+			if got != want {
+				t.Fatalf("got %+v, want %+v", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for fan-out")
+		}
+	}
+}
+
+func TestMemoryBroker_DoesNotLeakAcrossRooms(t *testing.T) {
+	b := NewMemoryBroker()
+
+	messages, unsubscribe, err := b.Subscribe(context.Background(), "room-1")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer unsubscribe()
+
+	if err := b.Publish(context.Background(), Message{RoomId: "room-2", Kind: "message_created"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	select {
+	case got := <-messages:
+		t.Fatalf("subscriber of room-1 should not receive room-2's message, got %+v", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestMemoryBroker_UnsubscribeClosesTheChannel(t *testing.T) {
+	b := NewMemoryBroker()
+
+	messages, unsubscribe, err := b.Subscribe(context.Background(), "room-1")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	unsubscribe()
+
+	select {
+	case _, ok := <-messages:
+		if ok {
+			t.Fatal("expected the channel to be closed with no pending messages")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for channel close")
+	}
+}
@@ -0,0 +1,88 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// channelName maps a room id to the Postgres NOTIFY channel carrying its
+// messages. Channel identifiers can't be parameterized, so Subscribe quotes
+// the channel name in its LISTEN statement even though the room id is
+// always a uuid in practice.
+func channelName(roomId string) string {
+	return fmt.Sprintf("room_%s", roomId)
+}
+
+// PgBroker fans messages out across every API instance connected to the
+// same database using Postgres's LISTEN/NOTIFY.
+type PgBroker struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgBroker(pool *pgxpool.Pool) *PgBroker {
+	return &PgBroker{pool: pool}
+}
+
+func (b *PgBroker) Publish(ctx context.Context, message Message) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.pool.Exec(ctx, "SELECT pg_notify($1, $2)", channelName(message.RoomId), string(payload))
+	return err
+}
+
+func (b *PgBroker) Subscribe(ctx context.Context, roomId string) (<-chan Message, func(), error) {
+	conn, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	channel := channelName(roomId)
+	if _, err := conn.Exec(ctx, fmt.Sprintf("LISTEN %q", channel)); err != nil {
+		conn.Release()
+		return nil, nil, err
+	}
+
+	messages := make(chan Message, 16)
+	listenCtx, cancel := context.WithCancel(ctx)
+
+	go func() {
+		defer conn.Release()
+		defer close(messages)
+
+		for {
+			notification, err := conn.Conn().WaitForNotification(listenCtx)
+			if err != nil {
+				if listenCtx.Err() != nil {
+					return
+				}
+				slog.Error("Failed to wait for notification", "error", err.Error(), "room_id", roomId)
+				return
+			}
+
+			var message Message
+			if err := json.Unmarshal([]byte(notification.Payload), &message); err != nil {
+				slog.Error("Failed to decode broker notification", "error", err.Error())
+				continue
+			}
+
+			select {
+			case messages <- message:
+			case <-listenCtx.Done():
+				return
+			}
+		}
+	}()
+
+	unsubscribe := func() {
+		cancel()
+	}
+
+	return messages, unsubscribe, nil
+}